@@ -0,0 +1,18 @@
+package core
+
+import "fmt"
+
+// NormalizeCIK zero-pads cik to the 10-digit form the SEC's JSON data APIs
+// (submissions, XBRL company facts/concepts) expect in their URLs, e.g.
+// "320193" becomes "0000320193". CIKs already at or beyond that length are
+// returned unchanged.
+func NormalizeCIK(cik string) string {
+	return normalizeCIK(cik)
+}
+
+func normalizeCIK(cik string) string {
+	if len(cik) >= 10 {
+		return cik
+	}
+	return fmt.Sprintf("%010s", cik)
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/piquette/edgr/core/httpclient"
 	"github.com/piquette/edgr/core/model"
 	"golang.org/x/net/html/charset"
 )
@@ -69,19 +71,28 @@ func GetPublicCompanies() ([]Company, error) {
 // GetPublicCompanies returns a list of public companies.
 // The query parameter should include a key 'token' with a value for authentication purposes.
 func GetPublicCompaniesWithHeaders(queryParameters map[string]string, headers map[string]string) ([]Company, error) {
+	return GetPublicCompaniesWithHeadersContext(context.Background(), queryParameters, headers, nil)
+}
+
+// GetPublicCompaniesWithHeadersContext is the context-aware variant of GetPublicCompaniesWithHeaders.
+// The query parameter should include a key 'token' with a value for authentication purposes.
+// client is optional; when nil, httpclient.DefaultClient is used.
+func GetPublicCompaniesWithHeadersContext(ctx context.Context, queryParameters map[string]string, headers map[string]string, client *httpclient.Client) ([]Company, error) {
 
 	if queryParameters["token"] == "" {
 		return nil, fmt.Errorf("To access the endpoint at https://api.iex.cloud, you must include the 'token' in the query parameters.")
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	if client == nil {
+		client = httpclient.DefaultClient
+	}
 
 	parameters := url.Values{}
 	for k, v := range queryParameters {
 		parameters.Add(k, v)
 	}
 
-	req, err := http.NewRequest("GET", iexSymbolsURL + "?" + parameters.Encode(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", iexSymbolsURL + "?" + parameters.Encode(), nil)
 	if err != nil {
 		return []Company{}, err
 	}
@@ -116,15 +127,28 @@ func GetFiler(symbol string) (filer *model.Filer, err error) {
 	return GetFilerWithHeaders(symbol, map[string]string{})
 }
 
+// GetFilerContext is the context-aware variant of GetFiler.
+func GetFilerContext(ctx context.Context, symbol string) (filer *model.Filer, err error) {
+	return GetFilerWithHeadersContext(ctx, symbol, map[string]string{}, nil)
+}
+
 // GetFilerWithHeaders gets a single filer from the SEC website based on symbol, adding the given HTTP headers to the request.
 func GetFilerWithHeaders(symbol string, headers map[string]string) (filer *model.Filer, err error) {
+	return GetFilerWithHeadersContext(context.Background(), symbol, headers, nil)
+}
+
+// GetFilerWithHeadersContext is the context-aware variant of GetFilerWithHeaders.
+// client is optional; when nil, httpclient.DefaultClient is used.
+func GetFilerWithHeadersContext(ctx context.Context, symbol string, headers map[string]string, client *httpclient.Client) (filer *model.Filer, err error) {
+	if client == nil {
+		client = httpclient.DefaultClient
+	}
 
 	// get the cik for each symbol.
 	// tedious process...
 	url := fmt.Sprintf(secCompanyURL, symbol)
-	client := &http.Client{Timeout: 10 * time.Second}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return
 	}
@@ -176,7 +200,66 @@ type SECFiling struct {
 
 // GetFilings gets a list of filings for a single CIK.
 func GetFilings(cik, formtype, stoptime string) (filings []SECFiling, err error) {
+	return GetFilingsContext(context.Background(), cik, formtype, stoptime, nil)
+}
+
+// GetFilingsContext is the context-aware variant of GetFilings. The supplied context
+// covers the entire crawl of the CIK's archive directory: it is checked between page
+// fetches so that a caller-supplied deadline or cancellation stops the walk promptly,
+// rather than waiting out the full per-request timeout for each remaining filing.
+// client is optional; when nil, httpclient.DefaultClient is used.
+//
+// This defaults to the JSON submissions API (GetFilingsJSON, by way of
+// StreamFilings) and falls back to scraping the HTML archive directory
+// (getFilingsHTMLContext) if any part of that fails — not just the initial
+// submissions fetch, but also a failure fetching one of a long-history
+// filer's older shards. A partial JSON result is discarded in favor of a full
+// HTML crawl rather than being returned silently incomplete, since callers of
+// the non-streaming API have no way to tell a short result from a truncated
+// one.
+func GetFilingsContext(ctx context.Context, cik, formtype, stoptime string, client *httpclient.Client) (filings []SECFiling, err error) {
+	if client == nil {
+		client = httpclient.DefaultClient
+	}
+
+	opts := FilingQuery{}
+	if formtype != "" {
+		opts.FormTypes = []string{formtype}
+	}
+	if stoptime != "" {
+		since, parseErr := time.Parse("2006-01-02", stoptime)
+		if parseErr != nil {
+			return filings, parseErr
+		}
+		opts.Since = since
+	}
+
+	stream, err := StreamFilingsClient(ctx, client, cik, opts)
+	if err != nil {
+		log.Println("JSON submissions API failed, falling back to HTML archive scraping:", err)
+		return getFilingsHTMLContext(ctx, cik, formtype, stoptime, client)
+	}
 
+	for result := range stream {
+		if result.Err != nil {
+			log.Println("JSON submissions API failed partway through, falling back to HTML archive scraping:", result.Err)
+			// Drain the rest of the stream in the background so its producer/worker
+			// goroutines can exit instead of blocking on a channel nobody reads.
+			go func() {
+				for range stream {
+				}
+			}()
+			return getFilingsHTMLContext(ctx, cik, formtype, stoptime, client)
+		}
+		filings = append(filings, *result.Filing)
+	}
+	return filings, nil
+}
+
+// getFilingsHTMLContext is the original implementation of GetFilings, which
+// walks and scrapes the HTML archive directory page by page. It is kept as a
+// fallback for GetFilingsContext when the JSON submissions API is unavailable.
+func getFilingsHTMLContext(ctx context.Context, cik, formtype, stoptime string, client *httpclient.Client) (filings []SECFiling, err error) {
 	var stop *time.Time
 	if stoptime != "" {
 		t, err := time.Parse("2006-01-02", stoptime)
@@ -186,7 +269,7 @@ func GetFilings(cik, formtype, stoptime string) (filings []SECFiling, err error)
 		stop = &t
 	}
 
-	dirPage, err := getPage("https://www.sec.gov/Archives/edgar/data/"+cik, 2)
+	dirPage, err := getPageContext(ctx, client, "https://www.sec.gov/Archives/edgar/data/"+cik, 2)
 	if err != nil {
 		return
 	}
@@ -194,7 +277,11 @@ func GetFilings(cik, formtype, stoptime string) (filings []SECFiling, err error)
 	urls := findListURLs(dirPage)
 
 	for _, u := range urls {
-		docsPage, getErr := getPage(u, 2)
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		docsPage, getErr := getPageContext(ctx, client, u, 2)
 		if getErr != nil {
 			log.Println("couldnt find page:", getErr)
 			continue
@@ -206,7 +293,7 @@ func GetFilings(cik, formtype, stoptime string) (filings []SECFiling, err error)
 			continue
 		}
 
-		filing, buildErr := buildFiling(cik, idxURL)
+		filing, buildErr := buildFilingContext(ctx, client, cik, idxURL)
 		if buildErr != nil {
 			log.Println(buildErr)
 			continue
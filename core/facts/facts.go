@@ -0,0 +1,301 @@
+// Package facts talks to the SEC's XBRL structured-data APIs
+// (companyfacts, companyconcept, and frames), turning a filer's reported
+// financial tags into time series that are easy to plot or diff across years.
+package facts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/piquette/edgr/core"
+	"github.com/piquette/edgr/core/httpclient"
+)
+
+var (
+	companyFactsURL   = "https://data.sec.gov/api/xbrl/companyfacts/CIK%s.json"
+	companyConceptURL = "https://data.sec.gov/api/xbrl/companyconcept/CIK%s/%s/%s.json"
+	framesURL         = "https://data.sec.gov/api/xbrl/frames/%s/%s/%s/%s.json"
+)
+
+// Fact is a single point-in-time (or period) value reported for an XBRL tag.
+type Fact struct {
+	Value   float64
+	Unit    string
+	Start   time.Time // zero for instantaneous tags, e.g. balance-sheet items
+	End     time.Time
+	FiledAt time.Time
+	Accn    string
+	Form    string
+	FY      int
+	FP      string
+	Frame   string
+}
+
+// Concept is a single XBRL tag (e.g. us-gaap:Revenues) reported by a filer,
+// broken down by unit of measure.
+type Concept struct {
+	CIK         string
+	Taxonomy    string
+	Tag         string
+	Label       string
+	Description string
+	EntityName  string
+	Units       map[string][]Fact
+}
+
+// CompanyFacts is the full set of XBRL facts a filer has reported, organized
+// by taxonomy (e.g. "us-gaap", "dei") and then by tag.
+type CompanyFacts struct {
+	CIK        string
+	EntityName string
+	Facts      map[string]map[string]Concept
+}
+
+// TimeSeries returns the point-in-time values for tag/unit across every
+// taxonomy in which it appears, sorted by period end and deduplicated by
+// end — when the same period is reported more than once (e.g. an amended
+// filing restates a prior quarter, or a fiscal year reappears as a
+// comparative in later 10-Ks), the fact with the latest FiledAt wins.
+func (cf *CompanyFacts) TimeSeries(tag, unit string) ([]Fact, error) {
+	latest := map[time.Time]Fact{}
+
+	found := false
+	for _, tags := range cf.Facts {
+		concept, ok := tags[tag]
+		if !ok {
+			continue
+		}
+		facts, ok := concept.Units[unit]
+		if !ok {
+			continue
+		}
+		found = true
+		for _, f := range facts {
+			if existing, ok := latest[f.End]; !ok || f.FiledAt.After(existing.FiledAt) {
+				latest[f.End] = f
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("facts: no %q facts reported in unit %q", tag, unit)
+	}
+
+	series := make([]Fact, 0, len(latest))
+	for _, f := range latest {
+		series = append(series, f)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].End.Before(series[j].End) })
+	return series, nil
+}
+
+// GetCompanyFacts fetches every XBRL fact a filer has reported
+// (https://data.sec.gov/api/xbrl/companyfacts/CIK{cik}.json).
+func GetCompanyFacts(ctx context.Context, cik string) (*CompanyFacts, error) {
+	return GetCompanyFactsClient(ctx, nil, cik)
+}
+
+// GetCompanyFactsClient is the GetCompanyFacts variant that accepts an
+// optional *httpclient.Client; when nil, httpclient.DefaultClient is used.
+func GetCompanyFactsClient(ctx context.Context, client *httpclient.Client, cik string) (*CompanyFacts, error) {
+	paddedCIK := core.NormalizeCIK(cik)
+
+	var raw rawCompanyFacts
+	if err := fetchJSON(ctx, client, fmt.Sprintf(companyFactsURL, paddedCIK), &raw); err != nil {
+		return nil, err
+	}
+
+	cf := &CompanyFacts{
+		CIK:        cik,
+		EntityName: raw.EntityName,
+		Facts:      map[string]map[string]Concept{},
+	}
+	for taxonomy, tags := range raw.Facts {
+		cf.Facts[taxonomy] = map[string]Concept{}
+		for tag, rc := range tags {
+			cf.Facts[taxonomy][tag] = rc.toConcept(cik, taxonomy, tag, raw.EntityName)
+		}
+	}
+	return cf, nil
+}
+
+// GetConcept fetches a single XBRL tag for a filer
+// (https://data.sec.gov/api/xbrl/companyconcept/CIK{cik}/{taxonomy}/{tag}.json).
+func GetConcept(ctx context.Context, cik, taxonomy, tag string) (*Concept, error) {
+	return GetConceptClient(ctx, nil, cik, taxonomy, tag)
+}
+
+// GetConceptClient is the GetConcept variant that accepts an optional
+// *httpclient.Client; when nil, httpclient.DefaultClient is used.
+func GetConceptClient(ctx context.Context, client *httpclient.Client, cik, taxonomy, tag string) (*Concept, error) {
+	paddedCIK := core.NormalizeCIK(cik)
+
+	var raw rawConcept
+	url := fmt.Sprintf(companyConceptURL, paddedCIK, taxonomy, tag)
+	if err := fetchJSON(ctx, client, url, &raw); err != nil {
+		return nil, err
+	}
+
+	concept := raw.toConcept(cik, taxonomy, tag, raw.EntityName)
+	return &concept, nil
+}
+
+// Frames fetches every filer's reported value for tag/unit over a single
+// period (https://data.sec.gov/api/xbrl/frames/{taxonomy}/{tag}/{unit}/{period}.json).
+// period follows the SEC's frame notation, e.g. "CY2019Q1I" for an
+// instantaneous frame or "CY2019Q1" for a duration frame.
+func Frames(ctx context.Context, taxonomy, tag, unit, period string) ([]Fact, error) {
+	return FramesClient(ctx, nil, taxonomy, tag, unit, period)
+}
+
+// FramesClient is the Frames variant that accepts an optional
+// *httpclient.Client; when nil, httpclient.DefaultClient is used.
+func FramesClient(ctx context.Context, client *httpclient.Client, taxonomy, tag, unit, period string) ([]Fact, error) {
+	var raw rawFrames
+	url := fmt.Sprintf(framesURL, taxonomy, tag, unit, period)
+	if err := fetchJSON(ctx, client, url, &raw); err != nil {
+		return nil, err
+	}
+
+	facts := make([]Fact, 0, len(raw.Data))
+	for _, d := range raw.Data {
+		facts = append(facts, Fact{
+			Value: d.Val,
+			Unit:  unit,
+			End:   parseDate(d.End),
+			Start: parseDate(d.Start),
+			Accn:  d.Accn,
+			Frame: period,
+		})
+	}
+	return facts, nil
+}
+
+// fetchJSON fetches url and unmarshals the response body into out.
+func fetchJSON(ctx context.Context, client *httpclient.Client, url string, out interface{}) error {
+	if client == nil {
+		client = httpclient.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %s", err)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error unmarshaling JSON: %s", string(body))
+	}
+	return nil
+}
+
+// parseDate parses the "2006-01-02" dates the XBRL APIs use, returning the
+// zero time if s is empty or unparsable.
+func parseDate(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// rawCompanyFacts mirrors the companyfacts JSON response.
+type rawCompanyFacts struct {
+	CIK        int                              `json:"cik"`
+	EntityName string                           `json:"entityName"`
+	Facts      map[string]map[string]rawConcept `json:"facts"`
+}
+
+// rawConcept mirrors a single tag's JSON, shared by both the companyfacts and
+// companyconcept endpoints.
+type rawConcept struct {
+	Label       string               `json:"label"`
+	Description string               `json:"description"`
+	EntityName  string               `json:"entityName"`
+	Units       map[string][]rawFact `json:"units"`
+}
+
+// rawFact mirrors a single value entry under Units in the JSON response.
+type rawFact struct {
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	Val   float64 `json:"val"`
+	Accn  string  `json:"accn"`
+	FY    int     `json:"fy"`
+	FP    string  `json:"fp"`
+	Form  string  `json:"form"`
+	Filed string  `json:"filed"`
+	Frame string  `json:"frame"`
+}
+
+// rawFrames mirrors the frames JSON response. Pts is the number of data
+// points in the frame, not a label; the frame's period identifier is the
+// period string the caller requested, which Frames/FramesClient carries
+// straight into each Fact.Frame.
+type rawFrames struct {
+	Pts  int            `json:"pts"`
+	Data []rawFrameFact `json:"data"`
+}
+
+// rawFrameFact mirrors a single entry in the frames response's "data" array.
+type rawFrameFact struct {
+	Accn  string  `json:"accn"`
+	CIK   int     `json:"cik"`
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	Val   float64 `json:"val"`
+}
+
+func (rc rawConcept) toConcept(cik, taxonomy, tag, entityName string) Concept {
+	units := map[string][]Fact{}
+	for unit, rawFacts := range rc.Units {
+		facts := make([]Fact, 0, len(rawFacts))
+		for _, rf := range rawFacts {
+			facts = append(facts, Fact{
+				Value:   rf.Val,
+				Unit:    unit,
+				Start:   parseDate(rf.Start),
+				End:     parseDate(rf.End),
+				FiledAt: parseDate(rf.Filed),
+				Accn:    rf.Accn,
+				Form:    rf.Form,
+				FY:      rf.FY,
+				FP:      rf.FP,
+				Frame:   rf.Frame,
+			})
+		}
+		units[unit] = facts
+	}
+
+	name := rc.EntityName
+	if name == "" {
+		name = entityName
+	}
+
+	return Concept{
+		CIK:         cik,
+		Taxonomy:    taxonomy,
+		Tag:         tag,
+		Label:       rc.Label,
+		Description: rc.Description,
+		EntityName:  name,
+		Units:       units,
+	}
+}
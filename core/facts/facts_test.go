@@ -0,0 +1,163 @@
+package facts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/piquette/edgr/core/httpclient"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper so tests can serve
+// canned responses without making real network requests.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func testClient(t *testing.T, transport roundTripFunc) *httpclient.Client {
+	t.Helper()
+	client, err := httpclient.NewClient(httpclient.Options{
+		UserAgent: "edgr test suite test@example.com",
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+const companyFactsJSON = `{
+	"cik": 320193,
+	"entityName": "Apple Inc.",
+	"facts": {
+		"us-gaap": {
+			"Revenues": {
+				"label": "Revenues",
+				"description": "total revenue",
+				"units": {
+					"USD": [
+						{"start": "2021-10-01", "end": "2022-09-24", "val": 394328000000, "accn": "0000320193-22-000108", "fy": 2022, "fp": "FY", "form": "10-K", "filed": "2022-10-28"},
+						{"start": "2021-10-01", "end": "2022-09-24", "val": 394328000001, "accn": "0000320193-23-000077", "fy": 2022, "fp": "FY", "form": "10-K/A", "filed": "2023-08-04"},
+						{"start": "2022-09-25", "end": "2023-09-30", "val": 383285000000, "accn": "0000320193-23-000106", "fy": 2023, "fp": "FY", "form": "10-K", "filed": "2023-11-03"}
+					]
+				}
+			}
+		}
+	}
+}`
+
+const companyConceptJSON = `{
+	"cik": 320193,
+	"taxonomy": "us-gaap",
+	"tag": "Revenues",
+	"label": "Revenues",
+	"description": "total revenue",
+	"entityName": "Apple Inc.",
+	"units": {
+		"USD": [
+			{"start": "2022-09-25", "end": "2023-09-30", "val": 383285000000, "accn": "0000320193-23-000106", "fy": 2023, "fp": "FY", "form": "10-K", "filed": "2023-11-03"}
+		]
+	}
+}`
+
+func TestGetCompanyFactsClientDecodesJSON(t *testing.T) {
+	client := testClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(companyFactsJSON), nil
+	})
+
+	cf, err := GetCompanyFactsClient(context.Background(), client, "320193")
+	if err != nil {
+		t.Fatalf("GetCompanyFactsClient: %v", err)
+	}
+	if cf.EntityName != "Apple Inc." {
+		t.Fatalf("got EntityName %q, want Apple Inc.", cf.EntityName)
+	}
+	concept, ok := cf.Facts["us-gaap"]["Revenues"]
+	if !ok {
+		t.Fatal("missing us-gaap:Revenues concept")
+	}
+	if got := len(concept.Units["USD"]); got != 3 {
+		t.Fatalf("got %d raw facts, want 3", got)
+	}
+}
+
+func TestGetConceptClientDecodesJSON(t *testing.T) {
+	client := testClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(companyConceptJSON), nil
+	})
+
+	concept, err := GetConceptClient(context.Background(), client, "320193", "us-gaap", "Revenues")
+	if err != nil {
+		t.Fatalf("GetConceptClient: %v", err)
+	}
+	facts := concept.Units["USD"]
+	if len(facts) != 1 {
+		t.Fatalf("got %d facts, want 1", len(facts))
+	}
+	if facts[0].Value != 383285000000 {
+		t.Fatalf("got Value %v, want 383285000000", facts[0].Value)
+	}
+	if facts[0].Accn != "0000320193-23-000106" {
+		t.Fatalf("got Accn %q, want 0000320193-23-000106", facts[0].Accn)
+	}
+}
+
+func TestTimeSeriesSortsAndDedupesAcrossAmendedAccessions(t *testing.T) {
+	client := testClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(companyFactsJSON), nil
+	})
+	cf, err := GetCompanyFactsClient(context.Background(), client, "320193")
+	if err != nil {
+		t.Fatalf("GetCompanyFactsClient: %v", err)
+	}
+
+	series, err := cf.TimeSeries("Revenues", "USD")
+	if err != nil {
+		t.Fatalf("TimeSeries: %v", err)
+	}
+
+	// FY2022 was reported twice under different accession numbers (the
+	// original 10-K and a later-filed 10-K/A restating it); they share an
+	// End date and must collapse to one entry, keeping the later filing.
+	if got := len(series); got != 2 {
+		t.Fatalf("got %d facts, want 2 (FY2022 original + amendment should collapse)", got)
+	}
+	if series[0].Value != 394328000001 {
+		t.Fatalf("got FY2022 Value %v, want the amended 394328000001", series[0].Value)
+	}
+	if series[0].Accn != "0000320193-23-000077" {
+		t.Fatalf("got FY2022 Accn %q, want the later-filed amendment", series[0].Accn)
+	}
+	if !series[1].End.After(series[0].End) {
+		t.Fatal("series is not sorted by End ascending")
+	}
+}
+
+func TestTimeSeriesErrorsOnUnknownTagOrUnit(t *testing.T) {
+	client := testClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(companyFactsJSON), nil
+	})
+	cf, err := GetCompanyFactsClient(context.Background(), client, "320193")
+	if err != nil {
+		t.Fatalf("GetCompanyFactsClient: %v", err)
+	}
+
+	if _, err := cf.TimeSeries("Assets", "USD"); err == nil {
+		t.Fatal("want an error for a tag that was never reported")
+	}
+	if _, err := cf.TimeSeries("Revenues", "EUR"); err == nil {
+		t.Fatal("want an error for a unit that was never reported")
+	}
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetFilingsContextFallsBackFullyOnMidStreamShardError(t *testing.T) {
+	htmlDirRequested := false
+	client := testClient(t, func(req *http.Request) (*http.Response, error) {
+		switch {
+		case strings.Contains(req.URL.Path, "submissions-001"):
+			// The shard fetch returns a malformed body, so fetchSubmissions
+			// fails partway through the stream, after the 2 recent-page
+			// filings have already been emitted.
+			return jsonResponse("not valid json"), nil
+		case strings.Contains(req.URL.Path, "/submissions/"):
+			return jsonResponse(recentPageJSON), nil
+		case strings.Contains(req.URL.Path, "/Archives/edgar/data/"):
+			htmlDirRequested = true
+			// No directory entries match dirRegex, so the HTML fallback's
+			// walk has nothing to do and returns an empty, error-free result.
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewBufferString("<html></html>")),
+			}, nil
+		default:
+			return jsonResponse(recentPageJSON), nil
+		}
+	})
+
+	filings, err := GetFilingsContext(context.Background(), "320193", "", "", client)
+	if err != nil {
+		t.Fatalf("GetFilingsContext: %v", err)
+	}
+	if !htmlDirRequested {
+		t.Fatal("HTML archive directory was never requested; fallback did not run")
+	}
+	if len(filings) != 0 {
+		t.Fatalf("got %d filings, want 0 (the 2 filings emitted before the shard error must be discarded, not returned as a partial result)", len(filings))
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// dirPageWithThreeEntries is a minimal HTML archive directory listing with
+// three dirRegex-matched entries, in the same shape SEC's Archives index
+// pages use.
+const dirPageWithThreeEntries = `<html><body><table>
+<tr><td><a href="https://www.sec.gov/Archives/edgar/data/320193/000032019323000106/"><img src="folder.gif"></a></td></tr>
+<tr><td><a href="https://www.sec.gov/Archives/edgar/data/320193/000032019323000077/"><img src="folder.gif"></a></td></tr>
+<tr><td><a href="https://www.sec.gov/Archives/edgar/data/320193/000032019322000108/"><img src="folder.gif"></a></td></tr>
+</table></body></html>`
+
+// TestGetFilingsContextStopsHTMLWalkOnCancellation covers the founding claim
+// of the context-plumbing request: a deadline covering the whole crawl
+// should stop the HTML archive walk promptly instead of visiting every
+// remaining directory URL.
+func TestGetFilingsContextStopsHTMLWalkOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := testClient(t, func(req *http.Request) (*http.Response, error) {
+		switch {
+		case strings.Contains(req.URL.Path, "/submissions/"):
+			// Force the JSON path to fail immediately so GetFilingsContext
+			// falls back to the HTML walk before any of it runs.
+			return jsonResponse("not valid json"), nil
+		case req.URL.Path == "/Archives/edgar/data/320193":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewBufferString(dirPageWithThreeEntries)),
+			}, nil
+		case strings.Contains(req.URL.Path, "000032019323000106"):
+			// The first directory URL: cancel here, simulating the
+			// caller's deadline firing mid-crawl. The loop's ctx.Err()
+			// check at the top of its next iteration should return
+			// before the second or third directory URL is ever fetched.
+			cancel()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewBufferString("<html></html>")),
+			}, nil
+		case strings.Contains(req.URL.Path, "000032019323000077"), strings.Contains(req.URL.Path, "000032019322000108"):
+			t.Fatalf("directory URL %s was fetched after cancellation; the HTML walk did not stop promptly", req.URL)
+			return nil, nil
+		default:
+			t.Fatalf("unexpected request to %s", req.URL)
+			return nil, nil
+		}
+	})
+
+	if _, err := GetFilingsContext(ctx, "320193", "", "", client); err == nil {
+		t.Fatal("want a context-cancelled error, got nil")
+	}
+}
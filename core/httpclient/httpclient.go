@@ -0,0 +1,229 @@
+// Package httpclient provides a shared HTTP transport for talking to the SEC
+// EDGAR and IEX Cloud endpoints. SEC EDGAR requires every request to carry a
+// declared User-Agent and enforces a 10 req/sec ceiling per host; clients that
+// ignore either of those get rate limited or blocked outright. Client wraps a
+// plain *http.Client with a per-host token-bucket limiter and automatic retry
+// with backoff on 429/503 responses, so callers of core don't have to
+// reimplement throttling themselves.
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// HostSEC is the host for the general SEC EDGAR website (HTML archive, atom feeds).
+	HostSEC = "www.sec.gov"
+	// HostSECData is the host for the SEC's JSON data APIs (submissions, XBRL facts).
+	HostSECData = "data.sec.gov"
+	// HostIEX is the host for the IEX Cloud reference-data API.
+	HostIEX = "api.iex.cloud"
+
+	// DefaultSECRatePerSec is the default ceiling applied to the SEC hosts, kept
+	// below the documented 10 req/sec limit to leave headroom for jitter.
+	DefaultSECRatePerSec = 8
+	// DefaultIEXRatePerSec is the default ceiling applied to api.iex.cloud.
+	DefaultIEXRatePerSec = 50
+	// DefaultMaxRetries is the number of retry attempts made on 429/503 responses.
+	DefaultMaxRetries = 5
+)
+
+// Options configures a Client.
+type Options struct {
+	// UserAgent is sent on every request. The SEC rejects unidentified clients,
+	// so this is required: NewClient returns an error if it is empty.
+	UserAgent string
+	// SECRatePerSec is the token-bucket rate applied to HostSEC and HostSECData.
+	// Defaults to DefaultSECRatePerSec when zero.
+	SECRatePerSec float64
+	// IEXRatePerSec is the token-bucket rate applied to HostIEX.
+	// Defaults to DefaultIEXRatePerSec when zero.
+	IEXRatePerSec float64
+	// MaxRetries is the number of retry attempts made on 429/503 responses.
+	// Defaults to DefaultMaxRetries when zero.
+	MaxRetries int
+	// Timeout is the per-request timeout of the underlying http.Client.
+	// Defaults to 10 seconds when zero.
+	Timeout time.Duration
+	// Transport is the underlying http.RoundTripper. Defaults to
+	// http.DefaultTransport when nil; tests substitute a fake here instead of
+	// making real requests.
+	Transport http.RoundTripper
+}
+
+// Client is a rate-limited, retrying HTTP client for the SEC/IEX endpoints.
+type Client struct {
+	http       *http.Client
+	userAgent  string
+	maxRetries int
+	limiters   map[string]*tokenBucket
+}
+
+// NewClient builds a Client from the given Options. UserAgent must be set;
+// NewClient returns an error otherwise.
+func NewClient(opts Options) (*Client, error) {
+	if opts.UserAgent == "" {
+		return nil, fmt.Errorf("httpclient: UserAgent is required, the SEC blocks requests without one")
+	}
+
+	secRate := opts.SECRatePerSec
+	if secRate == 0 {
+		secRate = DefaultSECRatePerSec
+	}
+	iexRate := opts.IEXRatePerSec
+	if iexRate == 0 {
+		iexRate = DefaultIEXRatePerSec
+	}
+	maxRetries := opts.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Client{
+		http:       &http.Client{Timeout: timeout, Transport: opts.Transport},
+		userAgent:  opts.UserAgent,
+		maxRetries: maxRetries,
+		limiters: map[string]*tokenBucket{
+			HostSEC:     newTokenBucket(secRate),
+			HostSECData: newTokenBucket(secRate),
+			HostIEX:     newTokenBucket(iexRate),
+		},
+	}, nil
+}
+
+// Do sends req, waiting on the per-host rate limiter and setting the
+// configured User-Agent if the request does not already carry one. It retries
+// on 429/503 responses with exponential backoff and jitter, honoring a
+// Retry-After header when present.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	if limiter, ok := c.limiters[req.URL.Host]; ok {
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		resp, err = c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+			return resp, nil
+		}
+		if attempt == c.maxRetries {
+			return resp, nil
+		}
+
+		wait := retryAfter(resp.Header.Get("Retry-After"))
+		resp.Body.Close()
+		if wait == 0 {
+			wait = backoff(attempt)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	return resp, err
+}
+
+// backoff returns an exponential backoff duration with jitter for the given
+// (zero-based) retry attempt.
+func backoff(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt)) * 250 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) / 2))
+	return base + jitter
+}
+
+// retryAfter parses a Retry-After header value expressed in seconds, returning
+// zero if it is absent or unparsable.
+func retryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	secs, err := strconv.Atoi(header)
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// tokenBucket is a minimal token-bucket rate limiter, refilled continuously at
+// ratePerSec.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newTokenBucket(ratePerSec float64) *tokenBucket {
+	return &tokenBucket{
+		rate:     ratePerSec,
+		burst:    ratePerSec,
+		tokens:   ratePerSec,
+		lastFill: nowFunc(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := nowFunc()
+		elapsed := now.Sub(b.lastFill).Seconds()
+		b.lastFill = now
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// nowFunc is a var so tests can stub out wall-clock time.
+var nowFunc = time.Now
+
+// DefaultClient is the package-level Client used by core's exported functions
+// when no *Client is supplied. Its User-Agent identifies the edgr module
+// itself; production callers should construct their own Client with a
+// UserAgent that identifies their firm, as required by the SEC's fair access
+// policy.
+var DefaultClient, _ = NewClient(Options{UserAgent: "edgr (+https://github.com/piquette/edgr)"})
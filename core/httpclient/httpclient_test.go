@@ -0,0 +1,176 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper so tests can serve
+// canned responses without making real network requests.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newTestClient(t *testing.T, opts Options) *Client {
+	t.Helper()
+	if opts.UserAgent == "" {
+		opts.UserAgent = "edgr test suite test@example.com"
+	}
+	client, err := NewClient(opts)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestTokenBucketAllowsBurstThenThrottles(t *testing.T) {
+	b := newTokenBucket(2)
+
+	// A fresh bucket starts full, so the burst of 2 should be immediate.
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait %d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("burst of 2 took %s, want near-instant", elapsed)
+	}
+
+	// The 3rd request exhausts the bucket and must wait for a refill.
+	start = time.Now()
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait 3rd: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("3rd request took %s, want to block for a refill at 2/sec", elapsed)
+	}
+}
+
+func TestDoRetriesOn429AndGivesUpAfterMaxRetries(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, Options{
+		MaxRetries: 2,
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"0"}},
+				Body:       http.NoBody,
+			}, nil
+		}),
+	})
+
+	req, _ := http.NewRequest("GET", "https://www.sec.gov/Archives/edgar/data/320193", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("got status %d, want 429 after exhausting retries", resp.StatusCode)
+	}
+	if want := 3; calls != want { // initial attempt + 2 retries
+		t.Fatalf("got %d requests, want %d", calls, want)
+	}
+}
+
+func TestRetryAfterParsesSecondsAndIgnoresGarbage(t *testing.T) {
+	cases := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-1", 0},
+		{"not-a-number", 0},
+	}
+	for _, c := range cases {
+		if got := retryAfter(c.header); got != c.want {
+			t.Errorf("retryAfter(%q) = %s, want %s", c.header, got, c.want)
+		}
+	}
+}
+
+func TestDoHonorsRetryAfterOverComputedBackoff(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, Options{
+		MaxRetries: 1,
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Header:     http.Header{"Retry-After": []string{"1"}},
+					Body:       http.NoBody,
+				}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}),
+	})
+
+	req, _ := http.NewRequest("GET", "https://www.sec.gov/Archives/edgar/data/320193", nil)
+	start := time.Now()
+	resp, err := client.Do(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want 200 on the retry", resp.StatusCode)
+	}
+	if calls != 2 {
+		t.Fatalf("got %d requests, want 2", calls)
+	}
+	// backoff(0) alone would be ~250-375ms; a 1s Retry-After should dominate.
+	if elapsed < 900*time.Millisecond {
+		t.Fatalf("retry happened after %s, want to honor the 1s Retry-After header", elapsed)
+	}
+}
+
+func TestDoStopsRetryingOnContextCancellationMidBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	client := newTestClient(t, Options{
+		MaxRetries: 5,
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				// No Retry-After, so Do sleeps out the computed exponential
+				// backoff; cancel the context while it's sleeping.
+				go cancel()
+			}
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{},
+				Body:       http.NoBody,
+			}, nil
+		}),
+	})
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://www.sec.gov/Archives/edgar/data/320193", nil)
+
+	done := make(chan struct{})
+	var err error
+	go func() {
+		defer close(done)
+		_, err = client.Do(req)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Do did not return promptly after context cancellation")
+	}
+
+	if err != ctx.Err() {
+		t.Fatalf("got err %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Fatalf("got %d requests, want 1 (should not retry after cancellation)", calls)
+	}
+}
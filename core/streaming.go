@@ -0,0 +1,186 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/piquette/edgr/core/httpclient"
+)
+
+// FilingResult is a single item delivered on the channel returned by
+// StreamFilings: either a successfully retrieved Filing, or an Err
+// describing why one entry could not be retrieved. A result never carries
+// both.
+type FilingResult struct {
+	Filing *SECFiling
+	Err    error
+}
+
+// StreamFilings is the incremental, concurrent counterpart to GetFilingsJSON:
+// rather than accumulating every filing into a slice before returning, it
+// streams each one onto the returned channel as soon as it is available, and
+// fetches the filer's older filing-history shards (see FilingQuery and the
+// submissions API's "files" pointers) with a fixed-size worker pool instead
+// of one at a time. opts.Concurrency controls the pool size and defaults to
+// DefaultStreamConcurrency when zero.
+//
+// The channel is closed once every shard has been processed, opts.Limit has
+// been reached, or ctx is cancelled; callers should keep draining it until it
+// closes rather than abandoning it, so the worker goroutines can exit.
+func StreamFilings(ctx context.Context, cik string, opts FilingQuery) (<-chan FilingResult, error) {
+	return StreamFilingsClient(ctx, nil, cik, opts)
+}
+
+// DefaultStreamConcurrency is the worker-pool size StreamFilings uses when
+// opts.Concurrency is left at zero.
+const DefaultStreamConcurrency = 4
+
+// StreamFilingsClient is the StreamFilings variant that accepts an optional
+// *httpclient.Client; when nil, httpclient.DefaultClient is used.
+func StreamFilingsClient(ctx context.Context, client *httpclient.Client, cik string, opts FilingQuery) (<-chan FilingResult, error) {
+	if client == nil {
+		client = httpclient.DefaultClient
+	}
+
+	paddedCIK := normalizeCIK(cik)
+	resp, err := fetchSubmissions(ctx, client, fmt.Sprintf(submissionsURL, paddedCIK))
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultStreamConcurrency
+	}
+
+	// innerCtx is cancelled once opts.Limit is reached, so shard fetches and
+	// dispatch stop promptly instead of racing to fill an out channel nobody
+	// will read any more of.
+	innerCtx, cancel := context.WithCancel(ctx)
+	budget := newFilingBudget(opts.Limit)
+
+	out := make(chan FilingResult)
+	shards := make(chan submissionsFile)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for shard := range shards {
+				page, err := fetchSubmissions(innerCtx, client, "https://data.sec.gov/submissions/"+shard.Name)
+				if err != nil {
+					sendResult(innerCtx, out, FilingResult{Err: err})
+					continue
+				}
+				emitPage(innerCtx, cancel, budget, out, cik, opts, page.Filings.Recent)
+			}
+		}()
+	}
+
+	go func() {
+		defer cancel()
+		defer close(out)
+
+		// The recent page is already in hand; emit it directly rather than
+		// routing it through the worker pool.
+		emitPage(innerCtx, cancel, budget, out, cik, opts, resp.Filings.Recent)
+
+		go func() {
+			defer close(shards)
+			for _, f := range resp.Filings.Files {
+				select {
+				case shards <- f:
+				case <-innerCtx.Done():
+					return
+				}
+			}
+		}()
+
+		wg.Wait()
+	}()
+
+	return out, nil
+}
+
+// filingBudget is a shared, concurrency-safe counter enforcing FilingQuery.Limit
+// across emitPage calls running from the main-page goroutine and every shard
+// worker at once.
+type filingBudget struct {
+	limit     int64
+	unlimited bool
+	emitted   int64
+}
+
+func newFilingBudget(limit int) *filingBudget {
+	if limit <= 0 {
+		return &filingBudget{unlimited: true}
+	}
+	return &filingBudget{limit: int64(limit)}
+}
+
+// reserve atomically claims one slot in the budget, returning false once the
+// limit has already been reached.
+func (b *filingBudget) reserve() bool {
+	if b.unlimited {
+		return true
+	}
+	for {
+		cur := atomic.LoadInt64(&b.emitted)
+		if cur >= b.limit {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&b.emitted, cur, cur+1) {
+			return true
+		}
+	}
+}
+
+// emitPage filters a single submissions page against opts and sends each
+// matching filing to out, honoring the caller-supplied stoptime/Since cutoff
+// and Limit as it goes. Once budget is exhausted it stops emitting and calls
+// cancel so sibling shard fetches/workers shut down instead of continuing to
+// do work for results nobody wants.
+func emitPage(ctx context.Context, cancel context.CancelFunc, budget *filingBudget, out chan<- FilingResult, cik string, opts FilingQuery, page submissionsPage) {
+	for i, form := range page.Form {
+		if ctx.Err() != nil {
+			return
+		}
+		if len(opts.FormTypes) > 0 && !containsFormType(opts.FormTypes, form) {
+			continue
+		}
+
+		filingDate, err := time.Parse("2006-01-02", page.FilingDate[i])
+		if err != nil {
+			continue
+		}
+		if !opts.Since.IsZero() && filingDate.Before(opts.Since) {
+			continue
+		}
+		if !opts.Until.IsZero() && filingDate.After(opts.Until) {
+			continue
+		}
+
+		if !budget.reserve() {
+			cancel()
+			return
+		}
+
+		filing, err := buildFilingFromSubmission(cik, page, i, filingDate)
+		if err != nil {
+			sendResult(ctx, out, FilingResult{Err: err})
+			continue
+		}
+		sendResult(ctx, out, FilingResult{Filing: filing})
+	}
+}
+
+func sendResult(ctx context.Context, out chan<- FilingResult, r FilingResult) {
+	select {
+	case out <- r:
+	case <-ctx.Done():
+	}
+}
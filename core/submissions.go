@@ -0,0 +1,204 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/piquette/edgr/core/httpclient"
+	"github.com/piquette/edgr/core/model"
+)
+
+// submissionsURL is the SEC's per-filer JSON submissions endpoint. %s is the
+// CIK, zero-padded to 10 digits.
+var submissionsURL = "https://data.sec.gov/submissions/CIK%s.json"
+
+// FilingQuery narrows the filings returned by GetFilingsJSON.
+type FilingQuery struct {
+	// FormTypes restricts results to the given forms (e.g. "10-K", "8-K").
+	// All forms are returned when empty.
+	FormTypes []string
+	// Since excludes filings dated before this time. Zero value means no lower bound.
+	Since time.Time
+	// Until excludes filings dated after this time. Zero value means no upper bound.
+	Until time.Time
+	// Limit caps the number of filings returned, most recent first. Zero means no cap.
+	Limit int
+	// Concurrency controls the worker-pool size StreamFilings uses to fetch a
+	// filer's older filing-history shards in parallel. Ignored by
+	// GetFilingsJSON. Zero means DefaultStreamConcurrency.
+	Concurrency int
+}
+
+// submissionsResponse mirrors the subset of data.sec.gov/submissions/CIK*.json
+// that GetFilingsJSON needs.
+type submissionsResponse struct {
+	CIK     string `json:"cik"`
+	Name    string `json:"name"`
+	Filings struct {
+		Recent submissionsPage   `json:"recent"`
+		Files  []submissionsFile `json:"files"`
+	} `json:"filings"`
+}
+
+// submissionsFile points at an older shard of filing history, returned
+// alongside the "recent" page once a filer's history outgrows it.
+type submissionsFile struct {
+	Name string `json:"name"`
+}
+
+// submissionsPage is the parallel-array format the submissions API uses for
+// each page of filing history.
+type submissionsPage struct {
+	Form            []string `json:"form"`
+	FilingDate      []string `json:"filingDate"`
+	ReportDate      []string `json:"reportDate"`
+	AccessionNumber []string `json:"accessionNumber"`
+	PrimaryDocument []string `json:"primaryDocument"`
+	Items           []string `json:"items"`
+	Size            []int    `json:"size"`
+	IsXBRL          []int    `json:"isXBRL"`
+	IsInlineXBRL    []int    `json:"isInlineXBRL"`
+}
+
+// GetFilingsJSON gets a list of filings for a single CIK using the SEC's JSON
+// submissions API (https://data.sec.gov/submissions/CIK{cik}.json), rather
+// than walking and scraping the HTML archive directory page by page. This
+// collapses the N+1 requests GetFilings makes (one per filing directory) into
+// a single request plus, for filers with long histories, one additional
+// request per older shard referenced in the response's "files".
+func GetFilingsJSON(ctx context.Context, cik string, opts FilingQuery) ([]SECFiling, error) {
+	return GetFilingsJSONClient(ctx, nil, cik, opts)
+}
+
+// GetFilingsJSONClient is the GetFilingsJSON variant that accepts an optional
+// *httpclient.Client; when nil, httpclient.DefaultClient is used.
+func GetFilingsJSONClient(ctx context.Context, client *httpclient.Client, cik string, opts FilingQuery) ([]SECFiling, error) {
+	if client == nil {
+		client = httpclient.DefaultClient
+	}
+
+	paddedCIK := normalizeCIK(cik)
+
+	resp, err := fetchSubmissions(ctx, client, fmt.Sprintf(submissionsURL, paddedCIK))
+	if err != nil {
+		return nil, err
+	}
+
+	var filings []SECFiling
+	if done, err := appendMatchingFilings(&filings, cik, resp.Filings.Recent, opts); err != nil {
+		return nil, err
+	} else if done {
+		return filings, nil
+	}
+
+	// Older shards are fetched lazily, one at a time, so a caller-supplied
+	// Limit already satisfied by the recent page doesn't cost a single round
+	// trip to data.sec.gov for a filer's older history.
+	for _, f := range resp.Filings.Files {
+		shard, err := fetchSubmissions(ctx, client, "https://data.sec.gov/submissions/"+f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if done, err := appendMatchingFilings(&filings, cik, shard.Filings.Recent, opts); err != nil {
+			return nil, err
+		} else if done {
+			return filings, nil
+		}
+	}
+
+	return filings, nil
+}
+
+// appendMatchingFilings filters page against opts and appends each matching
+// filing to filings, stopping as soon as opts.Limit is reached. It reports
+// done=true once the limit is hit, so callers can stop fetching further
+// shards instead of continuing to page through history nobody asked for.
+func appendMatchingFilings(filings *[]SECFiling, cik string, page submissionsPage, opts FilingQuery) (done bool, err error) {
+	for i, form := range page.Form {
+		if len(opts.FormTypes) > 0 && !containsFormType(opts.FormTypes, form) {
+			continue
+		}
+
+		filingDate, err := time.Parse("2006-01-02", page.FilingDate[i])
+		if err != nil {
+			continue
+		}
+		if !opts.Since.IsZero() && filingDate.Before(opts.Since) {
+			continue
+		}
+		if !opts.Until.IsZero() && filingDate.After(opts.Until) {
+			continue
+		}
+
+		filing, err := buildFilingFromSubmission(cik, page, i, filingDate)
+		if err != nil {
+			return false, err
+		}
+		*filings = append(*filings, *filing)
+
+		if opts.Limit > 0 && len(*filings) >= opts.Limit {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fetchSubmissions fetches and decodes a single submissions JSON document.
+func fetchSubmissions(ctx context.Context, client *httpclient.Client, url string) (*submissionsResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %s", err)
+	}
+
+	var result submissionsResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %s", string(body))
+	}
+	return &result, nil
+}
+
+// buildFilingFromSubmission assembles a SECFiling from entry i of a
+// submissions page, deriving the primary document URL deterministically
+// rather than fetching the filing's own index page.
+func buildFilingFromSubmission(cik string, page submissionsPage, i int, filingDate time.Time) (*SECFiling, error) {
+	accession := page.AccessionNumber[i]
+	docURL := fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/%s/%s/%s",
+		cik, strings.ReplaceAll(accession, "-", ""), page.PrimaryDocument[i])
+
+	return &SECFiling{
+		Filing: &model.Filing{
+			CIK:        cik,
+			FormType:   page.Form[i],
+			EdgarTime:  filingDate,
+			AllSymbols: []string{},
+		},
+		Docs: []*model.Document{
+			{URL: docURL},
+		},
+	}, nil
+}
+
+func containsFormType(formTypes []string, form string) bool {
+	for _, f := range formTypes {
+		if f == form {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetFilingsJSONClientStopsFetchingShardsOnceLimitIsMet(t *testing.T) {
+	shardFetched := false
+	client := testClient(t, func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "submissions-001") {
+			shardFetched = true
+			return jsonResponse(shardPageJSON), nil
+		}
+		return jsonResponse(recentPageJSON), nil
+	})
+
+	filings, err := GetFilingsJSONClient(context.Background(), client, "320193", FilingQuery{Limit: 1})
+	if err != nil {
+		t.Fatalf("GetFilingsJSONClient: %v", err)
+	}
+	if len(filings) != 1 {
+		t.Fatalf("got %d filings, want 1", len(filings))
+	}
+	if shardFetched {
+		t.Fatal("fetched the older shard even though Limit was already met by the recent page")
+	}
+}
@@ -0,0 +1,63 @@
+package watch
+
+import (
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltBucket is the single bucket BoltStore keeps seen-entry IDs in, keyed by
+// "<subscription key>|<atom entry id>".
+var boltBucket = []byte("watch_seen")
+
+// BoltStore is a file-backed Store, for Watchers that need seen-entry state
+// to survive a restart. It wraps a single bbolt database file.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a bbolt database at path for use
+// as a Watcher Store.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("watch: opening bolt store: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("watch: initializing bolt store: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the underlying database file.
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
+
+// Seen implements Store.
+func (b *BoltStore) Seen(key, id string) (bool, error) {
+	var seen bool
+	err := b.db.View(func(tx *bolt.Tx) error {
+		seen = tx.Bucket(boltBucket).Get(entryKey(key, id)) != nil
+		return nil
+	})
+	return seen, err
+}
+
+// MarkSeen implements Store.
+func (b *BoltStore) MarkSeen(key, id string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Put(entryKey(key, id), []byte{1})
+	})
+}
+
+func entryKey(key, id string) []byte {
+	return []byte(key + "|" + id)
+}
@@ -0,0 +1,351 @@
+// Package watch polls the SEC's per-company Atom feeds
+// (https://www.sec.gov/cgi-bin/browse-edgar?action=getcompany&...&output=atom)
+// for new filings and emits them on a channel as they appear, so callers don't
+// have to re-crawl a filer's whole history to notice a single new filing.
+package watch
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/piquette/edgr/core"
+	"github.com/piquette/edgr/core/httpclient"
+	"github.com/piquette/edgr/core/model"
+	"golang.org/x/net/html/charset"
+)
+
+// atomFeedURL is the per-company Atom feed endpoint. %s is the CIK, %s is the
+// form type filter (empty matches every form).
+var atomFeedURL = "https://www.sec.gov/cgi-bin/browse-edgar?action=getcompany&CIK=%s&type=%s&output=atom&count=40"
+
+// accessionRegexp pulls the accession number out of an Atom entry's <id>,
+// which EDGAR renders as a urn containing "accession-number=...".
+var accessionRegexp = regexp.MustCompile(`accession-number=([0-9-]+)`)
+
+const (
+	// DefaultInterval is the polling interval used when Options.Interval is zero.
+	DefaultInterval = 5 * time.Minute
+	// maxBackoffPolls caps how many polls a repeatedly-304'd or 429'd
+	// subscription is skipped for before being retried anyway.
+	maxBackoffPolls = 6
+)
+
+// Subscription identifies a single (CIK, form types) feed to poll. An empty
+// Forms matches every form type EDGAR reports for the CIK.
+type Subscription struct {
+	CIK   string
+	Forms []string
+}
+
+func (s Subscription) key() string {
+	return s.CIK + "|" + strings.Join(s.Forms, ",")
+}
+
+// Store tracks which Atom entry IDs have already been emitted for a
+// subscription, so a Watcher restarted against the same Store does not
+// re-emit filings it has already delivered.
+type Store interface {
+	// Seen reports whether id has already been recorded for key.
+	Seen(key, id string) (bool, error)
+	// MarkSeen records id as seen for key.
+	MarkSeen(key, id string) error
+}
+
+// MemoryStore is the default in-process Store; it does not survive restarts.
+type MemoryStore struct {
+	mu   sync.Mutex
+	seen map[string]map[string]struct{}
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{seen: map[string]map[string]struct{}{}}
+}
+
+// Seen implements Store.
+func (m *MemoryStore) Seen(key, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.seen[key][id]
+	return ok, nil
+}
+
+// MarkSeen implements Store.
+func (m *MemoryStore) MarkSeen(key, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.seen[key] == nil {
+		m.seen[key] = map[string]struct{}{}
+	}
+	m.seen[key][id] = struct{}{}
+	return nil
+}
+
+// Options configures a Watcher.
+type Options struct {
+	// Interval is how often each subscription is polled. Defaults to
+	// DefaultInterval when zero.
+	Interval time.Duration
+	// Store tracks seen Atom entry IDs. Defaults to a fresh MemoryStore when nil.
+	Store Store
+	// Client is the rate-limited HTTP client used for polling. Defaults to
+	// httpclient.DefaultClient when nil.
+	Client *httpclient.Client
+}
+
+// Watcher polls one or more (CIK, form types) subscriptions for new filings.
+type Watcher struct {
+	interval time.Duration
+	store    Store
+	client   *httpclient.Client
+	events   chan core.SECFiling
+
+	mu            sync.Mutex
+	subs          map[string]Subscription
+	etags         map[string]string
+	lastModified  map[string]string
+	skipRemaining map[string]int
+}
+
+// NewWatcher builds a Watcher from the given Options.
+func NewWatcher(opts Options) *Watcher {
+	interval := opts.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+	store := opts.Store
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	client := opts.Client
+	if client == nil {
+		client = httpclient.DefaultClient
+	}
+
+	return &Watcher{
+		interval:      interval,
+		store:         store,
+		client:        client,
+		events:        make(chan core.SECFiling),
+		subs:          map[string]Subscription{},
+		etags:         map[string]string{},
+		lastModified:  map[string]string{},
+		skipRemaining: map[string]int{},
+	}
+}
+
+// Subscribe adds a (cik, forms) subscription to the Watcher. An empty forms
+// list matches every form type. Subscribe is safe to call while Run is active.
+func (w *Watcher) Subscribe(cik string, forms ...string) {
+	sub := Subscription{CIK: cik, Forms: forms}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs[sub.key()] = sub
+}
+
+// Unsubscribe removes a (cik, forms) subscription previously added with
+// Subscribe; forms must match exactly as given to Subscribe.
+func (w *Watcher) Unsubscribe(cik string, forms ...string) {
+	sub := Subscription{CIK: cik, Forms: forms}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.subs, sub.key())
+}
+
+// Events returns the channel on which new filings are emitted. It is closed
+// when Run returns.
+func (w *Watcher) Events() <-chan core.SECFiling {
+	return w.events
+}
+
+// Run polls every subscription on Watcher's configured interval until ctx is
+// cancelled, emitting newly-seen filings on Events(). It closes Events()
+// before returning.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.pollAll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.pollAll(ctx)
+		}
+	}
+}
+
+// pollAll polls every current subscription once.
+func (w *Watcher) pollAll(ctx context.Context) {
+	w.mu.Lock()
+	subs := make([]Subscription, 0, len(w.subs))
+	for _, sub := range w.subs {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		if ctx.Err() != nil {
+			return
+		}
+		w.poll(ctx, sub)
+	}
+}
+
+// poll fetches a single subscription's Atom feed and emits any filing whose
+// entry ID hasn't been seen before.
+func (w *Watcher) poll(ctx context.Context, sub Subscription) {
+	key := sub.key()
+
+	w.mu.Lock()
+	if remaining := w.skipRemaining[key]; remaining > 0 {
+		w.skipRemaining[key] = remaining - 1
+		w.mu.Unlock()
+		return
+	}
+	etag := w.etags[key]
+	lastModified := w.lastModified[key]
+	w.mu.Unlock()
+
+	formType := ""
+	if len(sub.Forms) == 1 {
+		formType = sub.Forms[0]
+	}
+	feedURL := fmt.Sprintf(atomFeedURL, url.QueryEscape(sub.CIK), url.QueryEscape(formType))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		log.Println("watch: building request:", err)
+		return
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		log.Println("watch: polling feed:", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified || resp.StatusCode == http.StatusTooManyRequests {
+		w.backOff(key)
+		return
+	}
+
+	w.mu.Lock()
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		w.etags[key] = etag
+	}
+	if lm := resp.Header.Get("Last-Modified"); lm != "" {
+		w.lastModified[key] = lm
+	}
+	w.skipRemaining[key] = 0
+	w.mu.Unlock()
+
+	var feed atomFeed
+	decoder := xml.NewDecoder(resp.Body)
+	decoder.CharsetReader = charset.NewReaderLabel
+	if err := decoder.Decode(&feed); err != nil {
+		log.Println("watch: decoding feed:", err)
+		return
+	}
+
+	for _, entry := range feed.Entries {
+		if len(sub.Forms) > 1 && !containsFormType(sub.Forms, entry.Category.Term) {
+			continue
+		}
+
+		seen, err := w.store.Seen(key, entry.ID)
+		if err != nil {
+			log.Println("watch: checking store:", err)
+			continue
+		}
+		if seen {
+			continue
+		}
+
+		filing := entry.toFiling(sub.CIK)
+		select {
+		case w.events <- filing:
+		case <-ctx.Done():
+			return
+		}
+
+		if err := w.store.MarkSeen(key, entry.ID); err != nil {
+			log.Println("watch: updating store:", err)
+		}
+	}
+}
+
+// backOff skips the next few polls of a subscription that keeps returning
+// 304/429, so the Watcher doesn't hammer a feed that has nothing new to say.
+func (w *Watcher) backOff(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.skipRemaining[key] < maxBackoffPolls {
+		w.skipRemaining[key]++
+	}
+}
+
+func containsFormType(forms []string, form string) bool {
+	for _, f := range forms {
+		if f == form {
+			return true
+		}
+	}
+	return false
+}
+
+// atomFeed is the subset of EDGAR's per-company Atom feed that watch needs.
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Updated  string       `xml:"updated"`
+	Category atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// toFiling builds a core.SECFiling from an Atom entry. Only the fields
+// recoverable from the feed itself are populated; callers that need the full
+// filing (documents, form-specific fields) should follow up with
+// core.GetFilingsJSON or core.GetFilingsContext.
+func (e atomEntry) toFiling(cik string) core.SECFiling {
+	updated, _ := time.Parse(time.RFC3339, e.Updated)
+
+	filing := &model.Filing{
+		CIK:        cik,
+		FormType:   e.Category.Term,
+		EdgarTime:  updated,
+		AllSymbols: []string{},
+	}
+
+	var docs []*model.Document
+	if m := accessionRegexp.FindStringSubmatch(e.ID); m != nil {
+		docs = []*model.Document{{URL: "https://www.sec.gov/Archives/edgar/data/" + cik + "/" + strings.ReplaceAll(m[1], "-", "")}}
+	}
+
+	return core.SECFiling{Filing: filing, Docs: docs}
+}
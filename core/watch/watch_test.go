@@ -0,0 +1,122 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/piquette/edgr/core/httpclient"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper so tests can serve
+// canned responses without making real network requests.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+const feedWithTwoEntries = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <id>urn:tag:sec.gov,2008:accession-number=0000320193-23-000106</id>
+    <title>10-K</title>
+    <updated>2023-11-03T16:30:00-05:00</updated>
+    <category term="10-K"/>
+  </entry>
+  <entry>
+    <id>urn:tag:sec.gov,2008:accession-number=0000320193-23-000077</id>
+    <title>10-Q</title>
+    <updated>2023-08-04T16:30:00-05:00</updated>
+    <category term="10-Q"/>
+  </entry>
+</feed>`
+
+func newTestWatcher(t *testing.T, transport roundTripFunc) *Watcher {
+	t.Helper()
+	client, err := httpclient.NewClient(httpclient.Options{
+		UserAgent: "edgr test suite test@example.com",
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return NewWatcher(Options{Client: client, Store: NewMemoryStore()})
+}
+
+// pollAndCollect runs poll in a goroutine (since poll blocks sending to
+// w.events) and collects whatever it emits before returning.
+func pollAndCollect(w *Watcher, sub Subscription) []string {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	var ids []string
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case filing, ok := <-w.events:
+				if !ok {
+					return
+				}
+				ids = append(ids, filing.Filing.FormType)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	w.poll(context.Background(), sub)
+	cancel()
+	<-done
+	return ids
+}
+
+func TestWatcherDedupesByAtomID(t *testing.T) {
+	w := newTestWatcher(t, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewBufferString(feedWithTwoEntries)),
+		}, nil
+	})
+
+	sub := Subscription{CIK: "320193"}
+
+	first := pollAndCollect(w, sub)
+	if len(first) != 2 {
+		t.Fatalf("first poll: got %d filings, want 2", len(first))
+	}
+
+	second := pollAndCollect(w, sub)
+	if len(second) != 0 {
+		t.Fatalf("second poll: got %d filings, want 0 (entries already seen)", len(second))
+	}
+}
+
+func TestWatcherBackOffSkipsPollsOn304(t *testing.T) {
+	calls := 0
+	w := newTestWatcher(t, func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewBufferString("")),
+		}, nil
+	})
+
+	sub := Subscription{CIK: "320193"}
+
+	w.poll(context.Background(), sub)
+	if calls != 1 {
+		t.Fatalf("got %d requests after first poll, want 1", calls)
+	}
+
+	w.poll(context.Background(), sub)
+	if calls != 1 {
+		t.Fatalf("got %d requests after second poll, want 1 (should have been skipped by backoff)", calls)
+	}
+}